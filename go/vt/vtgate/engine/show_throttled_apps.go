@@ -0,0 +1,243 @@
+/*
+Copyright 2022 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// ShowThrottledApps is a Primitive that fans `SHOW VITESS_THROTTLED_APPS`
+// out to every shard of a keyspace and merges the per-shard results into a
+// single, deduplicated view: one row per app name, with its expiration
+// taken as the max across shards and a Shards column listing which shards
+// reported it. This spares operators from having to reconcile duplicate app
+// rows themselves when a keyspace has more than one shard.
+type ShowThrottledApps struct {
+	Keyspace          *vindexes.Keyspace
+	TargetDestination key.Destination
+	Query             string
+}
+
+var _ Primitive = (*ShowThrottledApps)(nil)
+
+// RouteType implements Primitive.
+func (s *ShowThrottledApps) RouteType() string {
+	return "ShowThrottledApps"
+}
+
+// GetKeyspaceName implements Primitive.
+func (s *ShowThrottledApps) GetKeyspaceName() string {
+	return s.Keyspace.Name
+}
+
+// GetTableName implements Primitive.
+func (s *ShowThrottledApps) GetTableName() string {
+	return ""
+}
+
+// NeedsTransaction implements Primitive.
+func (s *ShowThrottledApps) NeedsTransaction() bool {
+	return false
+}
+
+// Inputs implements Primitive.
+func (s *ShowThrottledApps) Inputs() []Primitive {
+	return nil
+}
+
+// GetFields implements Primitive.
+func (s *ShowThrottledApps) GetFields(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable) (*sqltypes.Result, error) {
+	return &sqltypes.Result{Fields: throttledAppsFields}, nil
+}
+
+// TryExecute implements Primitive.
+func (s *ShowThrottledApps) TryExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool) (*sqltypes.Result, error) {
+	rss, _, err := vcursor.ResolveDestinations(ctx, s.Keyspace.Name, nil, []key.Destination{s.TargetDestination})
+	if err != nil {
+		return nil, err
+	}
+
+	// Fan the query out to every shard in parallel: this is a scatter-gather
+	// over a keyspace that may have many shards, and serializing it here
+	// would turn one SHOW statement into N sequential round-trips.
+	results := make([]*sqltypes.Result, len(rss))
+	errs := make([]error, len(rss))
+
+	var wg sync.WaitGroup
+	for i, rs := range rss {
+		wg.Add(1)
+		go func(i int, rs *srvtopo.ResolvedShard) {
+			defer wg.Done()
+			results[i], errs[i] = vcursor.ExecuteStandalone(ctx, s, s.Query, bindVars, rs)
+		}(i, rs)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	agg := newThrottledAppsAggregator()
+	for i, rs := range rss {
+		agg.addRows(rs.Target.GetShard(), results[i].Rows)
+	}
+
+	return agg.result(), nil
+}
+
+// TryStreamExecute implements Primitive.
+func (s *ShowThrottledApps) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
+	qr, err := s.TryExecute(ctx, vcursor, bindVars, wantfields)
+	if err != nil {
+		return err
+	}
+	return callback(qr)
+}
+
+func (s *ShowThrottledApps) description() PrimitiveDescription {
+	return PrimitiveDescription{
+		OperatorType: "ShowThrottledApps",
+		Keyspace:     s.Keyspace,
+		Other: map[string]any{
+			"Query": s.Query,
+		},
+	}
+}
+
+// throttledAppsFields describes the columns of a SHOW VITESS_THROTTLED_APPS
+// result: the underlying per-shard columns (App, ExpiresAt, Ratio), plus a
+// Shards column added by the aggregation.
+var throttledAppsFields = []*querypb.Field{
+	{Name: "App", Type: sqltypes.VarChar},
+	{Name: "ExpiresAt", Type: sqltypes.VarChar},
+	{Name: "Ratio", Type: sqltypes.Float64},
+	{Name: "Shards", Type: sqltypes.VarChar},
+}
+
+// throttledAppsExpiresAtLayout is the MySQL DATETIME format the throttler
+// uses to render an app's expiration into the ExpiresAt column.
+const throttledAppsExpiresAtLayout = "2006-01-02 15:04:05"
+
+// throttledApp is the merged view of one throttled app across shards.
+type throttledApp struct {
+	expiresAt     sqltypes.Value
+	expiresAtTime time.Time
+	ratio         sqltypes.Value
+	shards        map[string]bool
+}
+
+// throttledAppsAggregator deduplicates SHOW VITESS_THROTTLED_APPS rows by
+// app name across shards, keeping the furthest-out expiration and recording
+// which shards reported each app.
+type throttledAppsAggregator struct {
+	apps map[string]*throttledApp
+}
+
+func newThrottledAppsAggregator() *throttledAppsAggregator {
+	return &throttledAppsAggregator{apps: make(map[string]*throttledApp)}
+}
+
+func (a *throttledAppsAggregator) addRows(shard string, rows []sqltypes.Row) {
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		app := row[0].ToString()
+
+		existing, ok := a.apps[app]
+		if !ok {
+			existing = &throttledApp{shards: make(map[string]bool)}
+			a.apps[app] = existing
+		}
+		existing.shards[shard] = true
+
+		expiresAt := row[1]
+		expiresAtTime, err := parseThrottledAppExpiresAt(expiresAt)
+		if err != nil {
+			// Can't compare this value numerically; keep whatever we
+			// already have rather than risk replacing a good value with
+			// an unparseable one.
+			continue
+		}
+		if !ok || expiresAtTime.After(existing.expiresAtTime) {
+			existing.expiresAt = expiresAt
+			existing.expiresAtTime = expiresAtTime
+			if len(row) > 2 {
+				existing.ratio = row[2]
+			}
+		}
+	}
+}
+
+// parseThrottledAppExpiresAt parses an ExpiresAt column value into a
+// time.Time so expirations can be compared numerically instead of as raw
+// strings (lexicographic comparison breaks on non-zero-padded or non-ISO
+// timestamp encodings).
+func parseThrottledAppExpiresAt(v sqltypes.Value) (time.Time, error) {
+	if v.IsIntegral() {
+		unixSeconds, err := v.ToInt64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(unixSeconds, 0), nil
+	}
+	return time.Parse(throttledAppsExpiresAtLayout, v.ToString())
+}
+
+func (a *throttledAppsAggregator) result() *sqltypes.Result {
+	appNames := make([]string, 0, len(a.apps))
+	for app := range a.apps {
+		appNames = append(appNames, app)
+	}
+	sort.Strings(appNames)
+
+	rows := make([]sqltypes.Row, 0, len(appNames))
+	for _, app := range appNames {
+		merged := a.apps[app]
+
+		shards := make([]string, 0, len(merged.shards))
+		for shard := range merged.shards {
+			shards = append(shards, shard)
+		}
+		sort.Strings(shards)
+
+		rows = append(rows, sqltypes.Row{
+			sqltypes.NewVarChar(app),
+			merged.expiresAt,
+			merged.ratio,
+			sqltypes.NewVarChar(strings.Join(shards, ",")),
+		})
+	}
+
+	return &sqltypes.Result{
+		Fields: throttledAppsFields,
+		Rows:   rows,
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/key"
+	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vtgate/vindexes"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+// throttledAppsFakeVCursor is a minimal VCursor stand-in for ShowThrottledApps
+// tests. It embeds the VCursor interface so the zero value satisfies methods
+// this test never calls, and overrides only ResolveDestinations and
+// ExecuteStandalone, the two ShowThrottledApps actually uses.
+type throttledAppsFakeVCursor struct {
+	VCursor
+
+	shards []string
+	// resultsByShard maps a shard name to either the result it should return
+	// or the error it should fail with.
+	resultsByShard map[string]*sqltypes.Result
+	errByShard     map[string]error
+}
+
+func (f *throttledAppsFakeVCursor) ResolveDestinations(ctx context.Context, keyspace string, ids []*querypb.Value, dests []key.Destination) ([]*srvtopo.ResolvedShard, [][]*querypb.Value, error) {
+	rss := make([]*srvtopo.ResolvedShard, 0, len(f.shards))
+	for _, shard := range f.shards {
+		rss = append(rss, &srvtopo.ResolvedShard{
+			Target: &querypb.Target{Keyspace: keyspace, Shard: shard},
+		})
+	}
+	return rss, nil, nil
+}
+
+func (f *throttledAppsFakeVCursor) ExecuteStandalone(ctx context.Context, primitive Primitive, query string, bindVars map[string]*querypb.BindVariable, rs *srvtopo.ResolvedShard) (*sqltypes.Result, error) {
+	shard := rs.Target.GetShard()
+	if err, ok := f.errByShard[shard]; ok {
+		return nil, err
+	}
+	return f.resultsByShard[shard], nil
+}
+
+func newThrottledAppsTestPrimitive() *ShowThrottledApps {
+	return &ShowThrottledApps{
+		Keyspace: &vindexes.Keyspace{Name: "ks"},
+		Query:    "show vitess_throttled_apps",
+	}
+}
+
+func TestShowThrottledAppsMergesAcrossShardsByLatestExpiration(t *testing.T) {
+	vc := &throttledAppsFakeVCursor{
+		shards: []string{"-80", "80-"},
+		resultsByShard: map[string]*sqltypes.Result{
+			// "-80" reports the app with an epoch-seconds ExpiresAt, the
+			// earlier of the two encodings.
+			"-80": {
+				Rows: []sqltypes.Row{
+					{sqltypes.NewVarChar("app1"), sqltypes.NewInt64(1000), sqltypes.NewFloat64(0.5)},
+				},
+			},
+			// "80-" reports the same app with a later MySQL DATETIME
+			// ExpiresAt, which should win the merge.
+			"80-": {
+				Rows: []sqltypes.Row{
+					{sqltypes.NewVarChar("app1"), sqltypes.NewVarChar("2286-11-20 17:46:40"), sqltypes.NewFloat64(0.9)},
+				},
+			},
+		},
+	}
+
+	qr, err := newThrottledAppsTestPrimitive().TryExecute(context.Background(), vc, nil, false)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+
+	row := qr.Rows[0]
+	assert.Equal(t, "app1", row[0].ToString())
+	assert.Equal(t, "2286-11-20 17:46:40", row[1].ToString())
+	assert.Equal(t, "0.9", row[2].ToString())
+	assert.Equal(t, "-80,80-", row[3].ToString())
+}
+
+func TestShowThrottledAppsShardsColumnSortedAndExact(t *testing.T) {
+	vc := &throttledAppsFakeVCursor{
+		shards: []string{"c0-", "-40", "40-c0"},
+		resultsByShard: map[string]*sqltypes.Result{
+			"c0-":   {Rows: []sqltypes.Row{{sqltypes.NewVarChar("app1"), sqltypes.NewInt64(1)}}},
+			"40-c0": {Rows: []sqltypes.Row{{sqltypes.NewVarChar("app1"), sqltypes.NewInt64(2)}}},
+			"-40":   {Rows: []sqltypes.Row{}},
+		},
+	}
+
+	qr, err := newThrottledAppsTestPrimitive().TryExecute(context.Background(), vc, nil, false)
+	require.NoError(t, err)
+	require.Len(t, qr.Rows, 1)
+
+	// Only the shards that actually reported the app, in sorted order -
+	// "-40" reported nothing and must not appear.
+	assert.Equal(t, "40-c0,c0-", qr.Rows[0][3].ToString())
+}
+
+func TestShowThrottledAppsPerShardErrorAbortsQuery(t *testing.T) {
+	vc := &throttledAppsFakeVCursor{
+		shards: []string{"-80", "80-"},
+		resultsByShard: map[string]*sqltypes.Result{
+			"-80": {Rows: []sqltypes.Row{{sqltypes.NewVarChar("app1"), sqltypes.NewInt64(1)}}},
+		},
+		errByShard: map[string]error{
+			"80-": fmt.Errorf("connection refused"),
+		},
+	}
+
+	_, err := newThrottledAppsTestPrimitive().TryExecute(context.Background(), vc, nil, false)
+	assert.ErrorContains(t, err, "connection refused")
+}
@@ -42,7 +42,7 @@ func buildShowThrottledAppsPlan(query string, vschema plancontext.VSchema) (*pla
 		dest = key.DestinationAllShards{}
 	}
 
-	return newPlanResult(&engine.Send{
+	return newPlanResult(&engine.ShowThrottledApps{
 		Keyspace:          ks,
 		TargetDestination: dest,
 		Query:             query,
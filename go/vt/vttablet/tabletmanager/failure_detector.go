@@ -0,0 +1,152 @@
+/*
+Copyright 2019 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"vitess.io/vitess/go/timer"
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// FailureDetector abstracts away the system that is responsible for
+// detecting MySQL failures and orchestrating failovers for a keyspace/shard.
+// TabletManager owns exactly one FailureDetector, chosen at startup via the
+// --failure_detector flag, and defers to it for self-registration and for
+// coordinating maintenance windows so that automated recovery does not
+// fight with operator-initiated actions (e.g. PlannedReparentShard).
+//
+// TabletManager's startup path (wherever it currently constructs/holds its
+// orcClient) should call NewFailureDetector(ts) once its *topo.Server is
+// available, store the result alongside its other dependencies, and launch
+// DiscoverLoop(fd, tm) as a background goroutine. PlannedReparentShard and
+// similar operator-initiated actions should call BeginMaintenance/
+// EndMaintenance around the window they don't want the failure detector
+// racing with.
+type FailureDetector interface {
+	// Discover tells the failure detector that this tablet exists and is
+	// reachable. It is called periodically from a background loop.
+	Discover(tablet *topodatapb.Tablet) error
+
+	// BeginMaintenance tells the failure detector not to act on this tablet
+	// until EndMaintenance is called. reason is a free-form string recorded
+	// for operator visibility.
+	BeginMaintenance(tablet *topodatapb.Tablet, reason string) error
+
+	// EndMaintenance removes a maintenance block placed by BeginMaintenance.
+	EndMaintenance(tablet *topodatapb.Tablet) error
+
+	// InActiveShardRecovery returns whether the failure detector currently
+	// believes it is in the middle of recovering this tablet's shard.
+	InActiveShardRecovery(tablet *topodatapb.Tablet) (bool, error)
+}
+
+// FailureDetectorFactory creates a new FailureDetector given the topo server
+// the caller is using. It returns a nil FailureDetector (and a nil error)
+// when the backend is intentionally disabled, e.g. because its required
+// flags were left empty. ts may be nil for backends that don't need a topo
+// server; a backend that does need one must return an error when ts is nil.
+type FailureDetectorFactory func(ts *topo.Server) (FailureDetector, error)
+
+var failureDetectorFactories = make(map[string]FailureDetectorFactory)
+
+// RegisterFailureDetectorFactory registers a FailureDetector implementation
+// under name so that it can be selected with --failure_detector=<name>.
+// It is meant to be called from init() in the file that implements the
+// backend, mirroring how other pluggable Vitess backends register
+// themselves.
+func RegisterFailureDetectorFactory(name string, factory FailureDetectorFactory) {
+	if _, ok := failureDetectorFactories[name]; ok {
+		panic(fmt.Sprintf("FailureDetectorFactory already registered for name %q", name))
+	}
+	failureDetectorFactories[name] = factory
+}
+
+var (
+	failureDetectorName     string
+	failureDetectorInterval time.Duration
+)
+
+func init() {
+	servenv.OnParseFor("vtcombo", registerFailureDetectorFlags)
+	servenv.OnParseFor("vttablet", registerFailureDetectorFlags)
+}
+
+func registerFailureDetectorFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&failureDetectorName, "failure_detector", "orc_http", "Which failure detector backend to use for failover coordination: \"orc_http\" (Orchestrator's HTTP API), \"topo\" (native leader-election via the topo server), or \"\" to disable.")
+	fs.DurationVar(&failureDetectorInterval, "failure_detector_discover_interval", 0, "How often to ping the failure detector backend to tell it we exist. 0 means never.")
+}
+
+// NewFailureDetector builds the FailureDetector selected by the
+// --failure_detector flag, wiring in ts for backends that need to talk to
+// the topo server. It should only be called after flags have been parsed.
+// A nil FailureDetector means the integration is disabled, either because no
+// backend was selected or because the selected backend decided its own
+// configuration (e.g. --orc_api_url) was left empty.
+func NewFailureDetector(ts *topo.Server) (FailureDetector, error) {
+	if failureDetectorName == "" {
+		return nil, nil
+	}
+	factory, ok := failureDetectorFactories[failureDetectorName]
+	if !ok {
+		return nil, fmt.Errorf("unknown --failure_detector %q", failureDetectorName)
+	}
+	return factory(ts)
+}
+
+// DiscoverLoop periodically calls fd.Discover() until process termination.
+// The Tablet is read from the given tm each time before calling Discover().
+// Usually this will be launched as a background goroutine.
+func DiscoverLoop(fd FailureDetector, tm *TabletManager) {
+	if failureDetectorInterval == 0 {
+		// 0 means never.
+		return
+	}
+	log.Infof("Starting periodic failure-detector self-registration: backend = %v, interval = %v", failureDetectorName, failureDetectorInterval)
+
+	// Randomly vary the interval by +/- 25% to reduce the potential for spikes.
+	ticker := timer.NewRandTicker(failureDetectorInterval, failureDetectorInterval/4)
+
+	// Remember whether we've most recently succeeded or failed.
+	var lastErr error
+
+	for {
+		// Do the first attempt immediately.
+		err := fd.Discover(tm.Tablet())
+
+		// Only log if we're transitioning between success and failure states.
+		if (err != nil) != (lastErr != nil) {
+			if err != nil {
+				log.Warningf("Failure-detector self-registration attempt failed: %v", err)
+			} else {
+				log.Infof("Failure-detector self-registration succeeded.")
+			}
+		}
+		lastErr = err
+
+		// Wait for the next tick.
+		// The only way to stop the loop is to terminate the process.
+		<-ticker.C
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/vt/topo"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func TestNewFailureDetectorDisabled(t *testing.T) {
+	old := failureDetectorName
+	defer func() { failureDetectorName = old }()
+
+	failureDetectorName = ""
+	fd, err := NewFailureDetector(nil)
+	require.NoError(t, err)
+	assert.Nil(t, fd)
+}
+
+func TestNewFailureDetectorUnknownBackend(t *testing.T) {
+	old := failureDetectorName
+	defer func() { failureDetectorName = old }()
+
+	failureDetectorName = "not_a_real_backend"
+	_, err := NewFailureDetector(nil)
+	assert.ErrorContains(t, err, "unknown --failure_detector")
+}
+
+func TestNewFailureDetectorFake(t *testing.T) {
+	old := failureDetectorName
+	defer func() { failureDetectorName = old }()
+
+	RegisterFailureDetectorFactory("fake_for_test", func(ts *topo.Server) (FailureDetector, error) {
+		return NewFakeFailureDetector(), nil
+	})
+
+	failureDetectorName = "fake_for_test"
+	fd, err := NewFailureDetector(nil)
+	require.NoError(t, err)
+	require.NotNil(t, fd)
+	_, ok := fd.(*FakeFailureDetector)
+	assert.True(t, ok)
+}
+
+func TestFakeFailureDetectorMaintenanceLifecycle(t *testing.T) {
+	fd := NewFakeFailureDetector()
+	tablet := &topodatapb.Tablet{
+		Keyspace: "ks",
+		Shard:    "-80",
+	}
+
+	require.NoError(t, fd.Discover(tablet))
+	assert.Equal(t, 1, fd.DiscoverCount)
+
+	active, err := fd.InActiveShardRecovery(tablet)
+	require.NoError(t, err)
+	assert.False(t, active)
+
+	require.NoError(t, fd.BeginMaintenance(tablet, "test maintenance"))
+	assert.True(t, fd.MaintenanceTabs[aliasKey(tablet)])
+
+	require.NoError(t, fd.EndMaintenance(tablet))
+	assert.False(t, fd.MaintenanceTabs[aliasKey(tablet)])
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"sync"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+// FakeFailureDetector is a FailureDetector used by tests that need to
+// observe or control maintenance/discovery calls without standing up a real
+// Orchestrator, VTOrc, or topo server.
+type FakeFailureDetector struct {
+	mu sync.Mutex
+
+	DiscoverCount    int
+	MaintenanceTabs  map[string]bool // "keyspace/shard" -> under maintenance
+	ActiveRecoveries map[string]bool // "keyspace/shard" -> in active recovery
+
+	DiscoverErr         error
+	BeginMaintenanceErr error
+	EndMaintenanceErr   error
+}
+
+var _ FailureDetector = (*FakeFailureDetector)(nil)
+
+// NewFakeFailureDetector returns a ready-to-use FakeFailureDetector.
+func NewFakeFailureDetector() *FakeFailureDetector {
+	return &FakeFailureDetector{
+		MaintenanceTabs:  make(map[string]bool),
+		ActiveRecoveries: make(map[string]bool),
+	}
+}
+
+// Discover implements FailureDetector.
+func (f *FakeFailureDetector) Discover(tablet *topodatapb.Tablet) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.DiscoverCount++
+	return f.DiscoverErr
+}
+
+// BeginMaintenance implements FailureDetector.
+func (f *FakeFailureDetector) BeginMaintenance(tablet *topodatapb.Tablet, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.BeginMaintenanceErr != nil {
+		return f.BeginMaintenanceErr
+	}
+	f.MaintenanceTabs[aliasKey(tablet)] = true
+	return nil
+}
+
+// EndMaintenance implements FailureDetector.
+func (f *FakeFailureDetector) EndMaintenance(tablet *topodatapb.Tablet) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.EndMaintenanceErr != nil {
+		return f.EndMaintenanceErr
+	}
+	delete(f.MaintenanceTabs, aliasKey(tablet))
+	return nil
+}
+
+// InActiveShardRecovery implements FailureDetector.
+func (f *FakeFailureDetector) InActiveShardRecovery(tablet *topodatapb.Tablet) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ActiveRecoveries[aliasKey(tablet)], nil
+}
+
+func aliasKey(tablet *topodatapb.Tablet) string {
+	return tablet.GetKeyspace() + "/" + tablet.GetShard()
+}
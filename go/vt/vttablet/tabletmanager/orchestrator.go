@@ -28,9 +28,8 @@ import (
 
 	"github.com/spf13/pflag"
 
-	"vitess.io/vitess/go/timer"
-	"vitess.io/vitess/go/vt/log"
 	"vitess.io/vitess/go/vt/servenv"
+	"vitess.io/vitess/go/vt/topo"
 	"vitess.io/vitess/go/vt/topo/topoproto"
 	"vitess.io/vitess/go/vt/vterrors"
 
@@ -42,12 +41,13 @@ var (
 	orcUser     string
 	orcPassword string
 	orcTimeout  = 30 * time.Second
-	orcInterval time.Duration
 )
 
 func init() {
 	servenv.OnParseFor("vtcombo", registerOrcFlags)
 	servenv.OnParseFor("vttablet", registerOrcFlags)
+
+	RegisterFailureDetectorFactory("orc_http", newOrcClient)
 }
 
 func registerOrcFlags(fs *pflag.FlagSet) {
@@ -55,17 +55,23 @@ func registerOrcFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&orcUser, "orc_api_user", orcUser, "(Optional) Basic auth username to authenticate with Orchestrator's HTTP API. Leave empty to disable basic auth.")
 	fs.StringVar(&orcPassword, "orc_api_password", orcPassword, "(Optional) Basic auth password to authenticate with Orchestrator's HTTP API.")
 	fs.DurationVar(&orcTimeout, "orc_timeout", orcTimeout, "Timeout for calls to Orchestrator's HTTP API.")
-	fs.DurationVar(&orcInterval, "orc_discover_interval", orcInterval, "How often to ping Orchestrator's HTTP API endpoint to tell it we exist. 0 means never.")
 }
 
+// orcClient is a FailureDetector backed by Orchestrator's HTTP API.
 type orcClient struct {
 	apiRoot    *url.URL
 	httpClient *http.Client
 }
 
-// newOrcClient creates a client for the Orchestrator HTTP API.
+var _ FailureDetector = (*orcClient)(nil)
+
+// newOrcClient creates a FailureDetector for Orchestrator's HTTP API. ts is
+// unused: the HTTP integration talks only to Orchestrator, never to the topo
+// server.
+// It returns a nil FailureDetector when --orc_api_url is empty, which means
+// the Orchestrator integration is disabled.
 // It should only be called after flags have been parsed.
-func newOrcClient() (*orcClient, error) {
+func newOrcClient(ts *topo.Server) (FailureDetector, error) {
 	if orcAddr == "" {
 		// Orchestrator integration is disabled.
 		return nil, nil
@@ -80,42 +86,6 @@ func newOrcClient() (*orcClient, error) {
 	}, nil
 }
 
-// DiscoverLoop periodically calls orc.discover() until process termination.
-// The Tablet is read from the given tm each time before calling discover().
-// Usually this will be launched as a background goroutine.
-func (orc *orcClient) DiscoverLoop(tm *TabletManager) {
-	if orcInterval == 0 {
-		// 0 means never.
-		return
-	}
-	log.Infof("Starting periodic Orchestrator self-registration: API URL = %v, interval = %v", orcAddr, orcInterval)
-
-	// Randomly vary the interval by +/- 25% to reduce the potential for spikes.
-	ticker := timer.NewRandTicker(orcInterval, orcInterval/4)
-
-	// Remember whether we've most recently succeeded or failed.
-	var lastErr error
-
-	for {
-		// Do the first attempt immediately.
-		err := orc.Discover(tm.Tablet())
-
-		// Only log if we're transitioning between success and failure states.
-		if (err != nil) != (lastErr != nil) {
-			if err != nil {
-				log.Warningf("Orchestrator self-registration attempt failed: %v", err)
-			} else {
-				log.Infof("Orchestrator self-registration succeeded.")
-			}
-		}
-		lastErr = err
-
-		// Wait for the next tick.
-		// The only way to stop the loop is to terminate the process.
-		<-ticker.C
-	}
-}
-
 // Discover executes a single attempt to self-register with Orchestrator.
 func (orc *orcClient) Discover(tablet *topodatapb.Tablet) error {
 	host, port, err := mysqlHostPort(tablet)
@@ -0,0 +1,148 @@
+/*
+Copyright 2019 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletmanager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"vitess.io/vitess/go/vt/log"
+	"vitess.io/vitess/go/vt/topo"
+	"vitess.io/vitess/go/vt/topo/topoproto"
+
+	topodatapb "vitess.io/vitess/go/vt/proto/topodata"
+)
+
+func init() {
+	RegisterFailureDetectorFactory("topo", newTopoFailureDetector)
+}
+
+// shardLock holds what's needed to release a lock taken out with
+// topo.Server.LockShard. ctx is the locked context LockShard returned; it is
+// canceled the moment the lock is lost (e.g. a session expiry against the
+// topo backend), so checking ctx.Err() before unlocking tells us whether we
+// actually still hold the lock or are merely cleaning up bookkeeping for one
+// we already lost.
+type shardLock struct {
+	ctx    context.Context
+	unlock func(*error)
+}
+
+// topoFailureDetector is a FailureDetector that needs no external service.
+// Instead, it uses a lock in the topo server (etcd/consul/zk, whichever
+// backs the topo.Server) as a lease that tablets take out before acting on a
+// shard, so that concurrent maintenance windows on the same shard serialize
+// against each other without requiring Orchestrator or VTOrc to be running.
+type topoFailureDetector struct {
+	ts *topo.Server
+
+	mu    sync.Mutex
+	locks map[string]*shardLock // keyed by keyspace/shard
+}
+
+var _ FailureDetector = (*topoFailureDetector)(nil)
+
+// newTopoFailureDetector creates a FailureDetector backed by ts. It should
+// only be called after flags have been parsed and ts has been opened.
+func newTopoFailureDetector(ts *topo.Server) (FailureDetector, error) {
+	if ts == nil {
+		return nil, fmt.Errorf("--failure_detector=topo requires a topo server to be configured")
+	}
+	return &topoFailureDetector{
+		ts:    ts,
+		locks: make(map[string]*shardLock),
+	}, nil
+}
+
+// Discover is a no-op for the topo-native backend: membership is already
+// tracked by the tablet's own record in the topo server, so there is nothing
+// extra to self-register.
+func (td *topoFailureDetector) Discover(tablet *topodatapb.Tablet) error {
+	return nil
+}
+
+// BeginMaintenance takes out a shard-scoped lock in the topo server so that
+// other tablets participating in the same shard's maintenance coordination
+// see this shard as under maintenance.
+func (td *topoFailureDetector) BeginMaintenance(tablet *topodatapb.Tablet, reason string) error {
+	key := shardKey(tablet)
+
+	td.mu.Lock()
+	defer td.mu.Unlock()
+
+	if _, ok := td.locks[key]; ok {
+		// Already held; treat as idempotent.
+		return nil
+	}
+
+	lockCtx, unlock, err := td.ts.LockShard(context.Background(), tablet.GetKeyspace(), tablet.GetShard(), fmt.Sprintf("failure-detector maintenance: %s (tablet %v)", reason, topoproto.TabletAliasString(tablet.Alias)))
+	if err != nil {
+		return fmt.Errorf("failed to lock shard %s in topo server for maintenance: %w", key, err)
+	}
+
+	td.locks[key] = &shardLock{ctx: lockCtx, unlock: unlock}
+	return nil
+}
+
+// EndMaintenance releases the shard-scoped lock taken out by
+// BeginMaintenance.
+func (td *topoFailureDetector) EndMaintenance(tablet *topodatapb.Tablet) error {
+	key := shardKey(tablet)
+
+	td.mu.Lock()
+	defer td.mu.Unlock()
+
+	lock, ok := td.locks[key]
+	if !ok {
+		// Nothing to release.
+		return nil
+	}
+	delete(td.locks, key)
+
+	if err := lock.ctx.Err(); err != nil {
+		// We already lost the lock (e.g. the topo session expired); calling
+		// unlock would just report an error for a lock we no longer hold.
+		log.Warningf("topo maintenance lock for %v was already lost before EndMaintenance was called: %v", key, err)
+		return nil
+	}
+
+	var err error
+	lock.unlock(&err)
+	if err != nil {
+		log.Warningf("failed to release topo maintenance lock for %v: %v", key, err)
+	}
+	return err
+}
+
+// InActiveShardRecovery reports whether this process currently holds the
+// maintenance lock for the tablet's shard. Unlike Orchestrator/VTOrc, the
+// topo-native backend has no external recovery engine of its own: it only
+// tracks whether a coordinated maintenance window is in progress.
+func (td *topoFailureDetector) InActiveShardRecovery(tablet *topodatapb.Tablet) (bool, error) {
+	key := shardKey(tablet)
+
+	td.mu.Lock()
+	defer td.mu.Unlock()
+
+	_, ok := td.locks[key]
+	return ok, nil
+}
+
+func shardKey(tablet *topodatapb.Tablet) string {
+	return fmt.Sprintf("%s/%s", tablet.GetKeyspace(), tablet.GetShard())
+}
@@ -31,6 +31,7 @@ import (
 	"vitess.io/vitess/go/vt/dbconfigs"
 
 	"vitess.io/vitess/go/mysql"
+	"vitess.io/vitess/go/stats"
 	"vitess.io/vitess/go/timer"
 	"vitess.io/vitess/go/vt/vttablet/tabletserver/connpool"
 
@@ -57,6 +58,15 @@ var (
 	errUnintialized = "tabletserver uninitialized"
 
 	streamHealthBufferSize = flag.Uint("stream_health_buffer_size", 20, "max streaming health entries to buffer per streaming health client")
+
+	streamHealthMaxCoalesceLag = flag.Duration("stream_health_max_coalesce_lag", 10*time.Second, "how long a slow streaming health client may fall behind before we give up coalescing updates for it and close its stream, forcing a reconnect")
+
+	streamHealthInitialSchemaSnapshot = flag.Bool("stream_health_schema_initial_snapshot", true, "when a new schema-event subscriber connects, send it a synthetic SchemaChangeEvent for every table currently known in _vt.schemacopy so it doesn't have to assume its schema cache is stale")
+
+	healthStreamerCoalescedUpdates    = stats.NewCounter("HealthStreamerCoalescedUpdates", "Number of health broadcasts that were superseded by a newer one before a streaming health client could be sent the older one")
+	healthStreamerDroppedUpdates      = stats.NewCounter("HealthStreamerDroppedUpdates", "Number of schema-change updates evicted from a streaming health client's ring buffer because it was full")
+	healthStreamerClosedStreams       = stats.NewCounter("HealthStreamerClosedStreams", "Number of streaming health clients that were disconnected for stalling past --stream_health_max_coalesce_lag")
+	healthStreamerSchemaEventsDropped = stats.NewCounter("HealthStreamerSchemaEventsDropped", "Number of SchemaChangeEvent batches evicted from a SubscribeSchemaEvents subscriber's ring buffer because it was full")
 )
 
 // healthStreamer streams health information to callers.
@@ -68,7 +78,7 @@ type healthStreamer struct {
 	mu      sync.Mutex
 	ctx     context.Context
 	cancel  context.CancelFunc
-	clients map[chan *querypb.StreamHealthResponse]struct{}
+	clients map[*healthStreamerClient]struct{}
 	state   *querypb.StreamHealthResponse
 
 	history *history.History
@@ -78,6 +88,247 @@ type healthStreamer struct {
 	conns                  *connpool.Pool
 	initSuccess            bool
 	signalWhenSchemaChange bool
+
+	schemaEventsMu  sync.Mutex
+	schemaEventSubs map[*schemaEventSub]struct{}
+}
+
+// SchemaChangeKind identifies the kind of change a SchemaChangeEvent
+// represents.
+type SchemaChangeKind string
+
+const (
+	SchemaChangeCreate  SchemaChangeKind = "CREATE"
+	SchemaChangeAlter   SchemaChangeKind = "ALTER"
+	SchemaChangeDrop    SchemaChangeKind = "DROP"
+	SchemaChangeInitial SchemaChangeKind = "INITIAL"
+
+	// SchemaChangeResync is prepended, as the first event, to the batch that
+	// triggered it when a SubscribeSchemaEvents subscriber has fallen far
+	// enough behind that its ring buffer had to evict a pending batch. It
+	// carries no table-specific information; it tells the subscriber that
+	// it missed at least one event and must treat its schema cache as
+	// stale (e.g. by re-requesting a full snapshot) rather than silently
+	// keep trusting a view that's now incomplete.
+	SchemaChangeResync SchemaChangeKind = "RESYNC"
+)
+
+// SchemaChangeEvent describes a single table's schema change, as detected by
+// healthStreamer.reload diffing the _vt.schemacopy snapshot against the
+// freshly detected set of changed tables. These are delivered through
+// SubscribeSchemaEvents, a stream that is separate from the StreamHealth RPC
+// (query.proto's StreamHealthResponse isn't extended with this level of
+// per-table detail), so that subscribers such as the vtgate schema tracker
+// or vreplication can react to individual table changes instead of
+// re-fetching everything whenever RealtimeStats.TableSchemaChanged is
+// non-empty.
+type SchemaChangeEvent struct {
+	Table        string
+	ChangeKind   SchemaChangeKind
+	PreviousHash string
+	NewHash      string
+	Timestamp    time.Time
+}
+
+// healthStreamerClient holds one streaming health subscriber's pending
+// state. Rather than buffering every broadcast in a channel and closing it
+// the moment the client falls behind, each client keeps a single "latest
+// state" slot plus a small ring buffer of schema-change snapshots, and a
+// dedicated goroutine drains them into the client's output channel at
+// whatever pace the client can keep up with. Only a client that stalls for
+// longer than --stream_health_max_coalesce_lag gets disconnected.
+type healthStreamerClient struct {
+	out chan *querypb.StreamHealthResponse
+
+	wake chan struct{}
+	done chan struct{}
+
+	mu         sync.Mutex
+	latest     *querypb.StreamHealthResponse
+	schemaRing []*querypb.StreamHealthResponse
+
+	closeOnce sync.Once
+}
+
+func newHealthStreamerClient() *healthStreamerClient {
+	return &healthStreamerClient{
+		out:  make(chan *querypb.StreamHealthResponse, 1),
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+}
+
+// deliver queues shr for delivery to this client, coalescing it with any
+// not-yet-sent update of the same kind. Schema-change snapshots are kept in
+// a small ring buffer of their own so that a burst of schema changes isn't
+// collapsed down to just the last one; any other update simply replaces
+// whatever was previously pending.
+func (c *healthStreamerClient) deliver(shr *querypb.StreamHealthResponse, ringSize uint) {
+	c.mu.Lock()
+	if len(shr.RealtimeStats.GetTableSchemaChanged()) > 0 {
+		if len(c.schemaRing) > 0 && uint(len(c.schemaRing)) >= ringSize {
+			c.schemaRing = c.schemaRing[1:]
+			healthStreamerDroppedUpdates.Add(1)
+		}
+		c.schemaRing = append(c.schemaRing, shr)
+	} else {
+		if c.latest != nil {
+			healthStreamerCoalescedUpdates.Add(1)
+		}
+		c.latest = shr
+	}
+	c.mu.Unlock()
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next pops the next snapshot that should be sent to the client, preferring
+// queued schema-change deltas (which must each be seen individually) over
+// the coalesced latest state.
+func (c *healthStreamerClient) next() *querypb.StreamHealthResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.schemaRing) > 0 {
+		shr := c.schemaRing[0]
+		c.schemaRing = c.schemaRing[1:]
+		return shr
+	}
+	shr := c.latest
+	c.latest = nil
+	return shr
+}
+
+// run is the per-client publisher goroutine. It wakes up whenever new state
+// is queued and drains it into c.out at whatever pace the reader of c.out
+// allows, closing the stream only if the reader stalls for longer than
+// maxStall.
+func (c *healthStreamerClient) run(maxStall time.Duration) {
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-c.wake:
+		}
+
+		for {
+			shr := c.next()
+			if shr == nil {
+				break
+			}
+			select {
+			case c.out <- shr:
+			case <-time.After(maxStall):
+				log.Warning("A streaming health client stalled past --stream_health_max_coalesce_lag. Closing its stream.")
+				healthStreamerClosedStreams.Add(1)
+				c.close()
+				return
+			case <-c.done:
+				return
+			}
+		}
+	}
+}
+
+func (c *healthStreamerClient) close() {
+	c.closeOnce.Do(func() {
+		close(c.out)
+	})
+}
+
+func (c *healthStreamerClient) stop() {
+	close(c.done)
+}
+
+// schemaEventSub is one SubscribeSchemaEvents subscriber's pending state. It
+// mirrors healthStreamerClient's ring+goroutine delivery model instead of
+// dropping batches out from under a busy subscriber with a bare
+// non-blocking channel send: pending batches are kept in a small ring
+// buffer (so a burst of schema changes isn't silently collapsed or lost)
+// and drained by a dedicated goroutine at whatever pace the subscriber
+// reads at. Unlike healthStreamerClient, a subscriber that falls behind
+// doesn't get disconnected — closing would just make it reconnect and
+// re-request the same initial snapshot. Instead, once the ring is full the
+// oldest pending batch is evicted, healthStreamerSchemaEventsDropped is
+// incremented, and a SchemaChangeResync marker is queued so the subscriber
+// can tell its view is stale instead of silently missing a change.
+type schemaEventSub struct {
+	out  chan []*SchemaChangeEvent
+	wake chan struct{}
+	done chan struct{}
+
+	mu   sync.Mutex
+	ring [][]*SchemaChangeEvent
+}
+
+func newSchemaEventSub() *schemaEventSub {
+	return &schemaEventSub{
+		out:  make(chan []*SchemaChangeEvent, 1),
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+}
+
+// enqueue appends events as a new pending batch, evicting the oldest batch
+// and prepending a SchemaChangeResync marker to events if the ring was
+// already full.
+func (s *schemaEventSub) enqueue(events []*SchemaChangeEvent, ringSize uint) {
+	s.mu.Lock()
+	if len(s.ring) > 0 && uint(len(s.ring)) >= ringSize {
+		s.ring = s.ring[1:]
+		healthStreamerSchemaEventsDropped.Add(1)
+		events = append([]*SchemaChangeEvent{{ChangeKind: SchemaChangeResync, Timestamp: time.Now()}}, events...)
+	}
+	s.ring = append(s.ring, events)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *schemaEventSub) next() []*SchemaChangeEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.ring) == 0 {
+		return nil
+	}
+	batch := s.ring[0]
+	s.ring = s.ring[1:]
+	return batch
+}
+
+// run is the per-subscriber publisher goroutine, draining pending batches
+// into s.out. Unlike healthStreamerClient.run, there is no stall timeout:
+// a subscriber that stops reading just accumulates ring evictions (and
+// resync markers) rather than getting disconnected.
+func (s *schemaEventSub) run() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.wake:
+		}
+
+		for {
+			batch := s.next()
+			if batch == nil {
+				break
+			}
+			select {
+			case s.out <- batch:
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
+
+func (s *schemaEventSub) stop() {
+	close(s.done)
 }
 
 func newHealthStreamer(env tabletenv.Env, alias *topodatapb.TabletAlias) *healthStreamer {
@@ -96,7 +347,8 @@ func newHealthStreamer(env tabletenv.Env, alias *topodatapb.TabletAlias) *health
 		stats:              env.Stats(),
 		degradedThreshold:  env.Config().Healthcheck.DegradedThresholdSeconds.Get(),
 		unhealthyThreshold: sync2.NewAtomicDuration(env.Config().Healthcheck.UnhealthyThresholdSeconds.Get()),
-		clients:            make(map[chan *querypb.StreamHealthResponse]struct{}),
+		clients:            make(map[*healthStreamerClient]struct{}),
+		schemaEventSubs:    make(map[*schemaEventSub]struct{}),
 
 		state: &querypb.StreamHealthResponse{
 			Target:      &querypb.Target{},
@@ -154,11 +406,11 @@ func (hs *healthStreamer) Close() {
 }
 
 func (hs *healthStreamer) Stream(ctx context.Context, callback func(*querypb.StreamHealthResponse) error) error {
-	ch, hsCtx := hs.register()
+	c, hsCtx := hs.register()
 	if hsCtx == nil {
 		return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "tabletserver is shutdown")
 	}
-	defer hs.unregister(ch)
+	defer hs.unregister(c)
 
 	// trigger the initial schema reload
 	if hs.signalWhenSchemaChange {
@@ -171,9 +423,9 @@ func (hs *healthStreamer) Stream(ctx context.Context, callback func(*querypb.Str
 			return nil
 		case <-hsCtx.Done():
 			return vterrors.Errorf(vtrpcpb.Code_UNAVAILABLE, "tabletserver is shutdown")
-		case shr, ok := <-ch:
+		case shr, ok := <-c.out:
 			if !ok {
-				return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "stream health buffer overflowed. client should reconnect for up-to-date status")
+				return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "stream health client stalled too long. client should reconnect for up-to-date status")
 			}
 			if err := callback(shr); err != nil {
 				if err == io.EOF {
@@ -185,7 +437,7 @@ func (hs *healthStreamer) Stream(ctx context.Context, callback func(*querypb.Str
 	}
 }
 
-func (hs *healthStreamer) register() (chan *querypb.StreamHealthResponse, context.Context) {
+func (hs *healthStreamer) register() (*healthStreamerClient, context.Context) {
 	hs.mu.Lock()
 	defer hs.mu.Unlock()
 
@@ -193,19 +445,95 @@ func (hs *healthStreamer) register() (chan *querypb.StreamHealthResponse, contex
 		return nil, nil
 	}
 
-	ch := make(chan *querypb.StreamHealthResponse, *streamHealthBufferSize)
-	hs.clients[ch] = struct{}{}
+	c := newHealthStreamerClient()
+	hs.clients[c] = struct{}{}
+	go c.run(*streamHealthMaxCoalesceLag)
 
 	// Send the current state immediately.
-	ch <- proto.Clone(hs.state).(*querypb.StreamHealthResponse)
-	return ch, hs.ctx
+	c.deliver(proto.Clone(hs.state).(*querypb.StreamHealthResponse), *streamHealthBufferSize)
+
+	return c, hs.ctx
+}
+
+// initialSchemaSnapshot builds a synthetic SchemaChangeEvent for every table
+// currently on record in _vt.schemacopy, so that a newly connected subscriber
+// can populate its schema cache without a separate full rescan.
+func (hs *healthStreamer) initialSchemaSnapshot() ([]*SchemaChangeEvent, error) {
+	if hs.conns == nil {
+		return nil, nil
+	}
+	conn, err := hs.conns.Get(hs.ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Recycle()
+
+	hashes, err := hs.schemaHashes(hs.ctx, conn, "1 = 1")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	events := make([]*SchemaChangeEvent, 0, len(hashes))
+	for table, hash := range hashes {
+		events = append(events, &SchemaChangeEvent{
+			Table:      table,
+			ChangeKind: SchemaChangeInitial,
+			NewHash:    hash,
+			Timestamp:  now,
+		})
+	}
+	return events, nil
+}
+
+// SubscribeSchemaEvents registers a new subscriber for per-table
+// SchemaChangeEvents. This is a stream separate from Stream/StreamHealth:
+// query.proto's StreamHealthResponse isn't extended with per-table detail,
+// so schema-event subscribers (e.g. the vtgate schema tracker or
+// vreplication) get their own channel instead. The returned cancel func
+// must be called once the subscriber is done to avoid leaking the channel.
+func (hs *healthStreamer) SubscribeSchemaEvents() (<-chan []*SchemaChangeEvent, func()) {
+	sub := newSchemaEventSub()
+
+	hs.schemaEventsMu.Lock()
+	hs.schemaEventSubs[sub] = struct{}{}
+	hs.schemaEventsMu.Unlock()
+
+	go sub.run()
+
+	if hs.signalWhenSchemaChange && *streamHealthInitialSchemaSnapshot {
+		if events, err := hs.initialSchemaSnapshot(); err != nil {
+			log.Warningf("failed to build initial schema snapshot for new schema-event subscriber: %v", err)
+		} else if len(events) > 0 {
+			sub.enqueue(events, *streamHealthBufferSize)
+		}
+	}
+
+	cancel := func() {
+		hs.schemaEventsMu.Lock()
+		delete(hs.schemaEventSubs, sub)
+		hs.schemaEventsMu.Unlock()
+		sub.stop()
+	}
+	return sub.out, cancel
+}
+
+// publishSchemaEvents fans events out to every current SubscribeSchemaEvents
+// subscriber's ring buffer.
+func (hs *healthStreamer) publishSchemaEvents(events []*SchemaChangeEvent) {
+	hs.schemaEventsMu.Lock()
+	defer hs.schemaEventsMu.Unlock()
+	for sub := range hs.schemaEventSubs {
+		sub.enqueue(events, *streamHealthBufferSize)
+	}
 }
 
-func (hs *healthStreamer) unregister(ch chan *querypb.StreamHealthResponse) {
+func (hs *healthStreamer) unregister(c *healthStreamerClient) {
 	hs.mu.Lock()
 	defer hs.mu.Unlock()
 
-	delete(hs.clients, ch)
+	delete(hs.clients, c)
+	c.stop()
 }
 
 func (hs *healthStreamer) ChangeState(tabletType topodatapb.TabletType, terTimestamp time.Time, lag time.Duration, err error, serving bool) {
@@ -242,26 +570,8 @@ func (hs *healthStreamer) ChangeState(tabletType topodatapb.TabletType, terTimes
 }
 
 func (hs *healthStreamer) broadCastToClients(shr *querypb.StreamHealthResponse) {
-	for ch := range hs.clients {
-		select {
-		case ch <- shr:
-		default:
-			// We can't block this state change on broadcasting to a streaming health client, but we
-			// also don't want to silently fail to inform a streaming health client of a state change
-			// because it can allow a vtgate to get wedged in a state where it's wrong about whether
-			// a tablet is healthy and can't automatically recover (see
-			//  https://github.com/vitessio/vitess/issues/5445). If we can't send a health update
-			// to this client we'll close() the channel which will ultimate fail the streaming health
-			// RPC and cause vtgates to reconnect.
-			//
-			// An alternative approach for streaming health would be to force a periodic broadcast even
-			// when there hasn't been an update and/or move away from using channels toward a model where
-			// old updates can be purged from the buffer in favor of more recent updates (since only the
-			// most recent health state really matters to gates).
-			log.Warning("A streaming health buffer is full. Closing the channel")
-			close(ch)
-			delete(hs.clients, ch)
-		}
+	for c := range hs.clients {
+		c.deliver(shr, *streamHealthBufferSize)
 	}
 }
 
@@ -298,14 +608,10 @@ func (hs *healthStreamer) AppendDetails(details []*kv) []*kv {
 func (hs *healthStreamer) SetUnhealthyThreshold(v time.Duration) {
 	hs.unhealthyThreshold.Set(v)
 	shr := proto.Clone(hs.state).(*querypb.StreamHealthResponse)
-	for ch := range hs.clients {
-		select {
-		case ch <- shr:
-		default:
-			log.Info("Resetting health streamer clients due to unhealthy threshold change")
-			close(ch)
-			delete(hs.clients, ch)
-		}
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	for c := range hs.clients {
+		c.deliver(shr, *streamHealthBufferSize)
 	}
 }
 
@@ -362,6 +668,14 @@ func (hs *healthStreamer) reload() error {
 	del := fmt.Sprintf("%s AND %s", mysql.ClearSchemaCopy, tableNamePredicate)
 	upd := fmt.Sprintf("%s AND %s", mysql.InsertIntoSchemaCopy, tableNamePredicate)
 
+	// Snapshot the hashes _vt.schemacopy currently has on record for these
+	// tables before we overwrite them, so we can tell CREATE/ALTER/DROP
+	// apart once the reload is done.
+	prevHashes, err := hs.schemaHashes(ctx, conn, tableNamePredicate)
+	if err != nil {
+		return err
+	}
+
 	// Reload the schema in a transaction.
 	_, err = conn.Exec(ctx, "begin", 1, false)
 	if err != nil {
@@ -384,14 +698,68 @@ func (hs *healthStreamer) reload() error {
 		return err
 	}
 
+	newHashes, err := hs.schemaHashes(ctx, conn, tableNamePredicate)
+	if err != nil {
+		return err
+	}
+
+	events := schemaChangeEvents(tables, prevHashes, newHashes, time.Now())
+
 	hs.state.RealtimeStats.TableSchemaChanged = tables
 	shr := proto.Clone(hs.state).(*querypb.StreamHealthResponse)
 	hs.broadCastToClients(shr)
 	hs.state.RealtimeStats.TableSchemaChanged = nil
 
+	hs.publishSchemaEvents(events)
+
 	return nil
 }
 
+// schemaChangeEvents classifies each table in tables as a CREATE, ALTER, or
+// DROP by diffing prevHashes (the hashes _vt.schemacopy had on record before
+// the reload) against newHashes (after), and stamps the result with now.
+func schemaChangeEvents(tables []string, prevHashes, newHashes map[string]string, now time.Time) []*SchemaChangeEvent {
+	events := make([]*SchemaChangeEvent, 0, len(tables))
+	for _, table := range tables {
+		prevHash, hadPrev := prevHashes[table]
+		newHash, hasNew := newHashes[table]
+
+		var kind SchemaChangeKind
+		switch {
+		case !hadPrev:
+			kind = SchemaChangeCreate
+		case !hasNew:
+			kind = SchemaChangeDrop
+		default:
+			kind = SchemaChangeAlter
+		}
+
+		events = append(events, &SchemaChangeEvent{
+			Table:        table,
+			ChangeKind:   kind,
+			PreviousHash: prevHash,
+			NewHash:      newHash,
+			Timestamp:    now,
+		})
+	}
+	return events
+}
+
+// schemaHashes returns the hash that _vt.schemacopy currently has on record
+// for each table matching predicate, keyed by table name.
+func (hs *healthStreamer) schemaHashes(ctx context.Context, conn *connpool.DBConn, predicate string) (map[string]string, error) {
+	query := fmt.Sprintf("select table_name, hash from _vt.schemacopy where %s", predicate)
+	qr, err := conn.Exec(ctx, query, 10000, false)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(map[string]string, len(qr.Rows))
+	for _, row := range qr.Rows {
+		hashes[row[0].ToString()] = row[1].ToString()
+	}
+	return hashes, nil
+}
+
 func (hs *healthStreamer) InitSchemaLocked(conn *connpool.DBConn) (bool, error) {
 	for _, query := range mysql.VTDatabaseInit {
 		_, err := conn.Exec(hs.ctx, query, 1, false)
@@ -0,0 +1,168 @@
+/*
+Copyright 2020 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tabletserver
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestHealthStreamerClientCoalescesNonSchemaUpdates(t *testing.T) {
+	before := healthStreamerCoalescedUpdates.Get()
+
+	c := newHealthStreamerClient()
+	c.deliver(&querypb.StreamHealthResponse{RealtimeStats: &querypb.RealtimeStats{ReplicationLagSeconds: 1}}, 20)
+	c.deliver(&querypb.StreamHealthResponse{RealtimeStats: &querypb.RealtimeStats{ReplicationLagSeconds: 2}}, 20)
+
+	// Only the second update should still be pending: the first was
+	// superseded before anything ever read it off the client.
+	shr := c.next()
+	require.NotNil(t, shr)
+	assert.EqualValues(t, 2, shr.RealtimeStats.ReplicationLagSeconds)
+	assert.Nil(t, c.next())
+
+	assert.Equal(t, before+1, healthStreamerCoalescedUpdates.Get())
+}
+
+func TestHealthStreamerClientSchemaRingEvictsOldest(t *testing.T) {
+	before := healthStreamerDroppedUpdates.Get()
+
+	c := newHealthStreamerClient()
+	const ringSize = 2
+	for i := 1; i <= 3; i++ {
+		c.deliver(&querypb.StreamHealthResponse{
+			RealtimeStats: &querypb.RealtimeStats{TableSchemaChanged: []string{"t"}, ReplicationLagSeconds: uint32(i)},
+		}, ringSize)
+	}
+
+	// The oldest (ReplicationLagSeconds == 1) should have been evicted to
+	// make room, leaving only the 2nd and 3rd snapshots.
+	first := c.next()
+	require.NotNil(t, first)
+	assert.EqualValues(t, 2, first.RealtimeStats.ReplicationLagSeconds)
+
+	second := c.next()
+	require.NotNil(t, second)
+	assert.EqualValues(t, 3, second.RealtimeStats.ReplicationLagSeconds)
+
+	assert.Nil(t, c.next())
+	assert.Equal(t, before+1, healthStreamerDroppedUpdates.Get())
+}
+
+func TestHealthStreamerClientStallClosesStream(t *testing.T) {
+	before := healthStreamerClosedStreams.Get()
+
+	c := newHealthStreamerClient()
+	go c.run(10 * time.Millisecond)
+	defer c.stop()
+
+	// Don't drain c.out until the stall timeout has passed. The first update
+	// fills c.out's buffer of 1 without blocking; the second forces run() to
+	// actually try (and then give up on) delivering to a reader that doesn't
+	// show up in time.
+	c.deliver(&querypb.StreamHealthResponse{RealtimeStats: &querypb.RealtimeStats{ReplicationLagSeconds: 1}}, 20)
+	time.Sleep(20 * time.Millisecond)
+	c.deliver(&querypb.StreamHealthResponse{RealtimeStats: &querypb.RealtimeStats{ReplicationLagSeconds: 2}}, 20)
+	time.Sleep(50 * time.Millisecond)
+
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-c.out:
+			if !ok {
+				assert.Equal(t, before+1, healthStreamerClosedStreams.Get())
+				return
+			}
+			// Drain the buffered update that was sent before the stall and
+			// keep waiting for the close.
+		case <-deadline:
+			t.Fatal("timed out waiting for stalled client's stream to be closed")
+		}
+	}
+}
+
+func TestSchemaEventSubRingEvictsOldestAndQueuesResync(t *testing.T) {
+	before := healthStreamerSchemaEventsDropped.Get()
+
+	// Exercise enqueue/next directly, without the run() goroutine, so the
+	// ring's eviction behavior is deterministic instead of racing against
+	// whatever the publisher goroutine has already drained.
+	sub := newSchemaEventSub()
+	defer sub.stop()
+
+	const ringSize = 2
+	for i := 1; i <= 3; i++ {
+		sub.enqueue([]*SchemaChangeEvent{{Table: fmt.Sprintf("t%d", i), ChangeKind: SchemaChangeAlter}}, ringSize)
+	}
+
+	// The 1st batch was evicted to make room for the 3rd; the 2nd batch
+	// should come through untouched, and the 3rd should be preceded by a
+	// resync marker telling the subscriber it missed something.
+	first := sub.next()
+	require.Len(t, first, 1)
+	assert.Equal(t, "t2", first[0].Table)
+
+	second := sub.next()
+	require.Len(t, second, 2)
+	assert.Equal(t, SchemaChangeResync, second[0].ChangeKind)
+	assert.Equal(t, "t3", second[1].Table)
+
+	assert.Nil(t, sub.next())
+	assert.Equal(t, before+1, healthStreamerSchemaEventsDropped.Get())
+}
+
+func TestSchemaChangeEventsClassifiesCreateAlterDrop(t *testing.T) {
+	now := time.Now()
+	prevHashes := map[string]string{
+		"altered": "old-hash",
+		"dropped": "old-hash",
+	}
+	newHashes := map[string]string{
+		"altered": "new-hash",
+		"created": "new-hash",
+	}
+
+	events := schemaChangeEvents([]string{"created", "altered", "dropped"}, prevHashes, newHashes, now)
+	require.Len(t, events, 3)
+
+	byTable := make(map[string]*SchemaChangeEvent, len(events))
+	for _, e := range events {
+		byTable[e.Table] = e
+	}
+
+	assert.Equal(t, SchemaChangeCreate, byTable["created"].ChangeKind)
+	assert.Equal(t, "", byTable["created"].PreviousHash)
+	assert.Equal(t, "new-hash", byTable["created"].NewHash)
+
+	assert.Equal(t, SchemaChangeAlter, byTable["altered"].ChangeKind)
+	assert.Equal(t, "old-hash", byTable["altered"].PreviousHash)
+	assert.Equal(t, "new-hash", byTable["altered"].NewHash)
+
+	assert.Equal(t, SchemaChangeDrop, byTable["dropped"].ChangeKind)
+	assert.Equal(t, "old-hash", byTable["dropped"].PreviousHash)
+	assert.Equal(t, "", byTable["dropped"].NewHash)
+
+	for _, e := range events {
+		assert.Equal(t, now, e.Timestamp)
+	}
+}